@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"http2mcp/internal/common/config"
+)
+
+// Database is the persistence interface used by API handlers for state that
+// is not itself an MCP server configuration, such as remote OpenAPI source
+// descriptors tracked by the import reconciler.
+type Database interface {
+	// SaveOpenAPISource upserts the descriptor for a remote OpenAPI source
+	// being kept in sync by the reconciler.
+	SaveOpenAPISource(ctx context.Context, source *OpenAPISource) error
+	// ListOpenAPISources returns every registered remote source.
+	ListOpenAPISources(ctx context.Context) ([]*OpenAPISource, error)
+	// SaveImportJob upserts the current state of a background import job.
+	// record is opaque to Database (it is whatever the jobs package tracks)
+	// and is stored as-is; this interface has no method to read it back.
+	SaveImportJob(ctx context.Context, id string, record interface{}) error
+	// SaveOpenAPIRevision stores the previous MCPConfig for name so an
+	// upsert re-import can later be rolled back.
+	SaveOpenAPIRevision(ctx context.Context, name string, cfg *config.MCPConfig) error
+	// GetOpenAPIRevision returns the revision previously saved via
+	// SaveOpenAPIRevision, if any.
+	GetOpenAPIRevision(ctx context.Context, name string) (*config.MCPConfig, error)
+}
+
+// OpenAPISource is the persisted descriptor of a remote source being reconciled.
+type OpenAPISource struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Location     string    `json:"location"`
+	Ref          string    `json:"ref"`
+	Auth         string    `json:"auth"`
+	PollInterval string    `json:"pollInterval"`
+	TenantID     string    `json:"tenantId"`
+	Prefix       string    `json:"prefix"`
+	ServerName   string    `json:"serverName"`
+	ContentHash  string    `json:"contentHash"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	LastSyncedAt time.Time `json:"lastSyncedAt"`
+}
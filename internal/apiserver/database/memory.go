@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"http2mcp/internal/common/config"
+)
+
+// InMemory is a process-local Database implementation backed by a map. It is
+// useful for tests and for running the server without an external store
+// configured.
+type InMemory struct {
+	mu         sync.RWMutex
+	sources    map[string]*OpenAPISource
+	importJobs map[string]interface{}
+	revisions  map[string]*config.MCPConfig
+}
+
+// NewInMemory creates an empty in-memory Database.
+func NewInMemory() *InMemory {
+	return &InMemory{
+		sources:    make(map[string]*OpenAPISource),
+		importJobs: make(map[string]interface{}),
+		revisions:  make(map[string]*config.MCPConfig),
+	}
+}
+
+// SaveOpenAPISource implements Database.
+func (d *InMemory) SaveOpenAPISource(ctx context.Context, source *OpenAPISource) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sources[source.ID] = source
+	return nil
+}
+
+// ListOpenAPISources implements Database.
+func (d *InMemory) ListOpenAPISources(ctx context.Context) ([]*OpenAPISource, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	sources := make([]*OpenAPISource, 0, len(d.sources))
+	for _, source := range d.sources {
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// SaveImportJob implements Database.
+func (d *InMemory) SaveImportJob(ctx context.Context, id string, record interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.importJobs[id] = record
+	return nil
+}
+
+// SaveOpenAPIRevision implements Database.
+func (d *InMemory) SaveOpenAPIRevision(ctx context.Context, name string, cfg *config.MCPConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.revisions[name] = cfg
+	return nil
+}
+
+// GetOpenAPIRevision implements Database.
+func (d *InMemory) GetOpenAPIRevision(ctx context.Context, name string) (*config.MCPConfig, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	cfg, ok := d.revisions[name]
+	if !ok {
+		return nil, fmt.Errorf("no saved revision for %q", name)
+	}
+	return cfg, nil
+}
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"context"
+	"io"
+	"os"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"http2mcp/internal/apiserver/database"
+	"http2mcp/internal/apiserver/jobs"
 	"http2mcp/internal/common/config"
 	"http2mcp/internal/i18n"
 	"http2mcp/internal/mcp/storage"
@@ -17,6 +23,7 @@ type OpenAPI struct {
 	store    storage.Store
 	notifier notifier.Notifier
 	logger   *zap.Logger
+	jobs     *jobs.Manager
 }
 
 // NewOpenAPI creates a new OpenAPI handler
@@ -26,10 +33,14 @@ func NewOpenAPI(db database.Database, store storage.Store, ntf notifier.Notifier
 		store:    store,
 		notifier: ntf,
 		logger:   logger,
+		jobs:     jobs.NewManager(db),
 	}
 }
 
-// HandleImport handles OpenAPI import requests
+// HandleImport accepts an OpenAPI file, streams it to a temp location, and
+// converts/persists it in a background job tracked by the jobs subsystem.
+// It responds immediately with 202 Accepted and a jobId so large specs don't
+// tie up the request.
 func (h *OpenAPI) HandleImport(c *gin.Context) {
 	h.logger.Info("handling OpenAPI import request")
 
@@ -56,64 +67,107 @@ func (h *OpenAPI) HandleImport(c *gin.Context) {
 	}
 	defer f.Close()
 
-	// Read the file content
-	content := make([]byte, file.Size)
-	if _, err := f.Read(content); err != nil {
-		h.logger.Error("failed to read file content",
-			zap.String("filename", file.Filename),
-			zap.Error(err))
-		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to read file: "+err.Error()))
+	// Stream the upload to a temp file rather than holding it all in memory.
+	tmp, err := os.CreateTemp("", "http2mcp-openapi-import-")
+	if err != nil {
+		h.logger.Error("failed to create temp file", zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to stage upload: "+err.Error()))
 		return
 	}
+	if _, err := io.Copy(tmp, f); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		h.logger.Error("failed to stage uploaded file", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to stage upload: "+err.Error()))
+		return
+	}
+	tmp.Close()
 
-	// Read tenant and prefix from form
 	tenant := c.PostForm("tenantId")
 	prefix := c.PostForm("prefix")
 
-	h.logger.Debug("creating OpenAPI converter")
-	converter := openapi.NewConverter()
+	job := h.jobs.Create(uuid.NewString())
+	h.logger.Info("queued OpenAPI import job", zap.String("job_id", job.ID), zap.String("filename", file.Filename))
 
-	// Use provided tenant/prefix if not empty, else use default logic
-	var config *config.MCPConfig
+	go h.runImportJob(job.ID, tmp.Name(), tenant, prefix)
+
+	i18n.Accepted(i18n.SuccessOpenAPIImportQueued).
+		With("status", "accepted").
+		With("jobId", job.ID).
+		Send(c)
+}
+
+// runImportJob performs the actual conversion/persistence for a queued import
+// job and records its outcome for later retrieval via HandleGetImportJob.
+func (h *OpenAPI) runImportJob(jobID, specPath, tenant, prefix string) {
+	defer os.Remove(specPath)
+
+	ctx := context.Background()
+	h.jobs.Update(jobID, func(job *jobs.Job) { job.State = jobs.StateRunning })
+
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		h.logger.Error("failed to read staged OpenAPI file", zap.String("job_id", jobID), zap.Error(err))
+		h.jobs.Update(jobID, func(job *jobs.Job) { job.State = jobs.StateFailed; job.Error = err.Error() })
+		return
+	}
+	h.jobs.Update(jobID, func(job *jobs.Job) { job.Progress = 25 })
+
+	converter := openapi.NewConverter()
+	var cfg *config.MCPConfig
 	if tenant == "" && prefix == "" {
-		config, err = converter.Convert(content)
+		cfg, err = converter.Convert(content)
 	} else {
-		config, err = converter.ConvertWithOptions(content, tenant, prefix)
+		cfg, err = converter.ConvertWithOptions(content, tenant, prefix)
 	}
 	if err != nil {
-		h.logger.Error("failed to convert OpenAPI specification", zap.Error(err))
-		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to convert OpenAPI specification: "+err.Error()))
+		h.logger.Error("failed to convert OpenAPI specification", zap.String("job_id", jobID), zap.Error(err))
+		code := convertErrorCode(err)
+		h.jobs.Update(jobID, func(job *jobs.Job) { job.State = jobs.StateFailed; job.Error = err.Error(); job.ErrorCode = code })
 		return
 	}
+	h.jobs.Update(jobID, func(job *jobs.Job) { job.Progress = 60; job.ServerName = cfg.Name })
 
-	h.logger.Info("OpenAPI specification converted successfully",
-		zap.String("server_name", config.Name))
+	if err := h.store.Create(ctx, cfg); err != nil {
+		h.logger.Error("failed to create MCP server", zap.String("job_id", jobID), zap.String("server_name", cfg.Name), zap.Error(err))
+		h.jobs.Update(jobID, func(job *jobs.Job) { job.State = jobs.StateFailed; job.Error = err.Error() })
+		return
+	}
+	h.jobs.Update(jobID, func(job *jobs.Job) { job.Progress = 85 })
 
-	// Create the MCP server configuration
-	h.logger.Debug("creating MCP server configuration")
-	if err := h.store.Create(c.Request.Context(), config); err != nil {
-		h.logger.Error("failed to create MCP server",
-			zap.String("server_name", config.Name),
-			zap.Error(err))
-		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to create MCP server: "+err.Error()))
+	if err := h.notifier.NotifyUpdate(ctx, cfg); err != nil {
+		h.logger.Error("failed to notify gateway", zap.String("job_id", jobID), zap.String("server_name", cfg.Name), zap.Error(err))
+		h.jobs.Update(jobID, func(job *jobs.Job) { job.State = jobs.StateFailed; job.Error = err.Error() })
 		return
 	}
 
-	// Notify the gateway about the update
-	h.logger.Debug("notifying gateway about the update")
-	if err := h.notifier.NotifyUpdate(c.Request.Context(), config); err != nil {
-		h.logger.Error("failed to notify gateway",
-			zap.String("server_name", config.Name),
-			zap.Error(err))
-		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to notify gateway: "+err.Error()))
+	h.logger.Info("OpenAPI imported successfully", zap.String("job_id", jobID), zap.String("server_name", cfg.Name))
+	h.jobs.Update(jobID, func(job *jobs.Job) {
+		job.State = jobs.StateSucceeded
+		job.Progress = 100
+		job.Config = cfg
+	})
+}
+
+// HandleGetImportJob returns the current state of a background import job.
+func (h *OpenAPI) HandleGetImportJob(c *gin.Context) {
+	jobID := c.Param("jobId")
+	job, ok := h.jobs.Get(jobID)
+	if !ok {
+		i18n.RespondWithError(c, i18n.ErrOpenAPINotFound.WithParam("Reason", "Unknown import job: "+jobID))
 		return
 	}
 
-	h.logger.Info("OpenAPI imported successfully",
-		zap.String("server_name", config.Name))
+	i18n.OK(i18n.SuccessOpenAPIJobStatus).
+		With("status", "success").
+		With("job", job).
+		Send(c)
+}
 
-	i18n.Created(i18n.SuccessOpenAPIImported).
+// HandleListImportJobs lists all tracked background import jobs.
+func (h *OpenAPI) HandleListImportJobs(c *gin.Context) {
+	i18n.OK(i18n.SuccessOpenAPIJobStatus).
 		With("status", "success").
-		With("config", config).
+		With("jobs", h.jobs.List()).
 		Send(c)
 }
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"http2mcp/internal/common/config"
+	"http2mcp/internal/i18n"
+	"http2mcp/pkg/openapi"
+)
+
+// BulkImportEntryStatus describes the outcome of importing one archive entry.
+type BulkImportEntryStatus string
+
+const (
+	BulkImportEntryCreated BulkImportEntryStatus = "created"
+	BulkImportEntrySkipped BulkImportEntryStatus = "skipped"
+	BulkImportEntryFailed  BulkImportEntryStatus = "failed"
+)
+
+// BulkImportEntryResult reports what happened to a single archive entry.
+type BulkImportEntryResult struct {
+	Path       string                `json:"path"`
+	Status     BulkImportEntryStatus `json:"status"`
+	ServerName string                `json:"serverName,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// bulkImportManifestEntry lets manifest.yaml override per-file tenant/prefix/name.
+type bulkImportManifestEntry struct {
+	TenantID string `yaml:"tenantId"`
+	Prefix   string `yaml:"prefix"`
+	Name     string `yaml:"name"`
+}
+
+// HandleBulkImport imports every OpenAPI spec found in an uploaded .zip or
+// .tar.gz archive, reporting a per-entry result instead of aborting on the
+// first failure. An optional manifest.yaml in the archive can override the
+// tenant/prefix/name that would otherwise come from form fields.
+func (h *OpenAPI) HandleBulkImport(c *gin.Context) {
+	h.logger.Info("handling OpenAPI bulk import request")
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error("failed to get archive from request", zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to get file: "+err.Error()))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded archive", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to open file: "+err.Error()))
+		return
+	}
+	defer f.Close()
+
+	archive, err := io.ReadAll(f)
+	if err != nil {
+		h.logger.Error("failed to read archive", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to read file: "+err.Error()))
+		return
+	}
+
+	defaultTenant := c.PostForm("tenantId")
+	defaultPrefix := c.PostForm("prefix")
+
+	entries, err := extractArchiveEntries(archive, file.Filename)
+	if err != nil {
+		h.logger.Error("failed to extract archive", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to extract archive: "+err.Error()))
+		return
+	}
+
+	manifest := parseBulkImportManifest(entries)
+
+	results := make([]BulkImportEntryResult, len(entries))
+	// configByEntry maps an index into entries/results to its converted
+	// config, so later stages never have to re-derive the association by
+	// server name (which is not guaranteed unique across entries).
+	configByEntry := make(map[int]*config.MCPConfig, len(entries))
+
+	converter := openapi.NewConverter()
+	for i, entry := range entries {
+		if isManifestEntry(entry.path) {
+			results[i] = BulkImportEntryResult{Path: entry.path, Status: BulkImportEntrySkipped}
+			continue
+		}
+		if !isSpecEntry(entry.path) {
+			results[i] = BulkImportEntryResult{Path: entry.path, Status: BulkImportEntrySkipped, Error: "not a recognized OpenAPI spec file"}
+			continue
+		}
+
+		tenant, prefix := defaultTenant, defaultPrefix
+		if override, ok := manifest[entry.path]; ok {
+			if override.TenantID != "" {
+				tenant = override.TenantID
+			}
+			if override.Prefix != "" {
+				prefix = override.Prefix
+			}
+		}
+
+		cfg, err := converter.ConvertWithOptions(entry.content, tenant, prefix)
+		if err != nil {
+			results[i] = BulkImportEntryResult{Path: entry.path, Status: BulkImportEntryFailed, Error: err.Error()}
+			continue
+		}
+
+		if override, ok := manifest[entry.path]; ok && override.Name != "" {
+			cfg.Name = override.Name
+		}
+
+		configByEntry[i] = cfg
+		results[i] = BulkImportEntryResult{Path: entry.path, Status: BulkImportEntryCreated, ServerName: cfg.Name}
+	}
+
+	created := make([]*config.MCPConfig, 0, len(configByEntry))
+	for i, cfg := range configByEntry {
+		if err := h.store.Create(c.Request.Context(), cfg); err != nil {
+			h.logger.Error("failed to create MCP server", zap.String("server_name", cfg.Name), zap.Error(err))
+			results[i].Status = BulkImportEntryFailed
+			results[i].Error = "Failed to create MCP server: " + err.Error()
+			continue
+		}
+		created = append(created, cfg)
+	}
+
+	// Notify the gateway about every created config in a single batched call
+	// instead of one round-trip per entry. A failure here can't be pinned on
+	// one entry, so it marks every config that was part of the batch.
+	if len(created) > 0 {
+		if err := h.notifier.NotifyBulkUpdate(c.Request.Context(), created); err != nil {
+			h.logger.Error("failed to notify gateway about bulk update", zap.Error(err))
+			for i := range configByEntry {
+				if results[i].Status == BulkImportEntryCreated {
+					results[i].Status = BulkImportEntryFailed
+					results[i].Error = "Failed to notify gateway: " + err.Error()
+				}
+			}
+		}
+	}
+
+	h.logger.Info("OpenAPI bulk import finished", zap.Int("total", len(results)), zap.Int("created", len(created)))
+
+	i18n.Created(i18n.SuccessOpenAPIBulkImported).
+		With("status", "success").
+		With("results", results).
+		Send(c)
+}
+
+type archiveEntry struct {
+	path    string
+	content []byte
+}
+
+func extractArchiveEntries(archive []byte, filename string) ([]archiveEntry, error) {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return extractZipEntries(archive)
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		return extractTarGzEntries(archive)
+	default:
+		return extractZipEntries(archive)
+	}
+}
+
+func extractZipEntries(archive []byte) ([]archiveEntry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []archiveEntry
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{path: f.Name, content: content})
+	}
+	return entries, nil
+}
+
+func extractTarGzEntries(archive []byte) ([]archiveEntry, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	var entries []archiveEntry
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{path: hdr.Name, content: content})
+	}
+	return entries, nil
+}
+
+func isManifestEntry(path string) bool {
+	return filepath.Base(path) == "manifest.yaml" || filepath.Base(path) == "manifest.yml"
+}
+
+func isSpecEntry(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+func parseBulkImportManifest(entries []archiveEntry) map[string]bulkImportManifestEntry {
+	overrides := map[string]bulkImportManifestEntry{}
+	for _, entry := range entries {
+		if !isManifestEntry(entry.path) {
+			continue
+		}
+		var raw map[string]bulkImportManifestEntry
+		if err := yaml.Unmarshal(entry.content, &raw); err != nil {
+			continue
+		}
+		for path, override := range raw {
+			overrides[path] = override
+		}
+	}
+	return overrides
+}
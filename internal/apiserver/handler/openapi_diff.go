@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"http2mcp/internal/common/config"
+	"http2mcp/internal/i18n"
+	"http2mcp/pkg/openapi"
+)
+
+// ConfigDiff summarizes what changed between two revisions of an MCPConfig.
+type ConfigDiff struct {
+	AddedTools     []string `json:"addedTools,omitempty"`
+	RemovedTools   []string `json:"removedTools,omitempty"`
+	ChangedTools   []string `json:"changedTools,omitempty"`
+	AddedRouters   []string `json:"addedRouters,omitempty"`
+	RemovedRouters []string `json:"removedRouters,omitempty"`
+	AuthChanged    bool     `json:"authChanged"`
+}
+
+// HasChanges reports whether the diff found any difference at all.
+func (d ConfigDiff) HasChanges() bool {
+	return len(d.AddedTools) > 0 || len(d.RemovedTools) > 0 || len(d.ChangedTools) > 0 ||
+		len(d.AddedRouters) > 0 || len(d.RemovedRouters) > 0 || d.AuthChanged
+}
+
+// diffConfigs computes a structured diff between an existing config and a
+// freshly converted one, covering tools, routers, and auth.
+func diffConfigs(oldCfg, newCfg *config.MCPConfig) ConfigDiff {
+	if oldCfg == nil {
+		return ConfigDiff{AddedTools: toolNames(newCfg), AddedRouters: routerNames(newCfg)}
+	}
+
+	var diff ConfigDiff
+	oldTools := toolSet(oldCfg)
+	newTools := toolSet(newCfg)
+
+	for name, tool := range newTools {
+		old, existed := oldTools[name]
+		switch {
+		case !existed:
+			diff.AddedTools = append(diff.AddedTools, name)
+		case !toolsEqual(old, tool):
+			diff.ChangedTools = append(diff.ChangedTools, name)
+		}
+	}
+	for name := range oldTools {
+		if _, stillPresent := newTools[name]; !stillPresent {
+			diff.RemovedTools = append(diff.RemovedTools, name)
+		}
+	}
+
+	oldRouters := stringSet(routerNames(oldCfg))
+	newRouters := stringSet(routerNames(newCfg))
+	for name := range newRouters {
+		if !oldRouters[name] {
+			diff.AddedRouters = append(diff.AddedRouters, name)
+		}
+	}
+	for name := range oldRouters {
+		if !newRouters[name] {
+			diff.RemovedRouters = append(diff.RemovedRouters, name)
+		}
+	}
+
+	diff.AuthChanged = !authEqual(oldCfg, newCfg)
+
+	return diff
+}
+
+func toolNames(cfg *config.MCPConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Tools))
+	for _, tool := range cfg.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func toolSet(cfg *config.MCPConfig) map[string]config.ToolConfig {
+	set := make(map[string]config.ToolConfig, len(cfg.Tools))
+	for _, tool := range cfg.Tools {
+		set[tool.Name] = tool
+	}
+	return set
+}
+
+func toolsEqual(a, b config.ToolConfig) bool {
+	return a.Method == b.Method && a.Path == b.Path && a.Description == b.Description
+}
+
+func routerNames(cfg *config.MCPConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Routers))
+	for _, router := range cfg.Routers {
+		names = append(names, router.Name)
+	}
+	return names
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func authEqual(oldCfg, newCfg *config.MCPConfig) bool {
+	return oldCfg.Auth == newCfg.Auth
+}
+
+// Upsert re-imports are served by HandleUpsertImport, not HandleImport:
+// re-importing a spec under an existing server name computes a diff instead
+// of blindly clobbering the live config, and keeps the previous revision
+// around so it can be rolled back. HandleImport reads no mode field and
+// always creates.
+
+// HandleUpsertImport handles a synchronous file-upload import (unlike
+// HandleImport, which runs as a background job) and supports a form field
+// mode=upsert: when
+// set, it diffs the new spec against any existing MCPConfig with the same
+// name, keeps the previous revision around for HandleRollback, and honors
+// ?dryRun=true to preview the diff without persisting anything. Without
+// mode=upsert it behaves like a plain create, so callers that only ever
+// import fresh specs don't need to opt into diff/rollback bookkeeping.
+func (h *OpenAPI) HandleUpsertImport(c *gin.Context) {
+	h.logger.Info("handling OpenAPI upsert import request")
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error("failed to get file from request", zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to get file: "+err.Error()))
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to open file: "+err.Error()))
+		return
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		h.logger.Error("failed to read file content", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to read file: "+err.Error()))
+		return
+	}
+
+	tenant := c.PostForm("tenantId")
+	prefix := c.PostForm("prefix")
+	upsert := c.PostForm("mode") == "upsert"
+	dryRun := upsert && c.Query("dryRun") == "true"
+	configID := c.PostForm("configId")
+
+	converter := openapi.NewConverter()
+	newCfg, err := converter.ConvertWithOptions(content, tenant, prefix)
+	if err != nil {
+		h.respondConvertError(c, err)
+		return
+	}
+
+	if !upsert {
+		if err := h.store.Create(c.Request.Context(), newCfg); err != nil {
+			h.logger.Error("failed to create MCP server", zap.String("server_name", newCfg.Name), zap.Error(err))
+			i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to create MCP server: "+err.Error()))
+			return
+		}
+		if err := h.notifier.NotifyUpdate(c.Request.Context(), newCfg); err != nil {
+			h.logger.Error("failed to notify gateway", zap.String("server_name", newCfg.Name), zap.Error(err))
+			i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to notify gateway: "+err.Error()))
+			return
+		}
+
+		h.logger.Info("OpenAPI import succeeded", zap.String("server_name", newCfg.Name))
+		i18n.Created(i18n.SuccessOpenAPIImported).
+			With("status", "success").
+			With("config", newCfg).
+			Send(c)
+		return
+	}
+
+	lookupName := configID
+	if lookupName == "" {
+		lookupName = newCfg.Name
+	}
+
+	existing, err := h.store.Get(c.Request.Context(), lookupName)
+	if err != nil {
+		existing = nil
+	}
+
+	diff := diffConfigs(existing, newCfg)
+
+	if dryRun {
+		i18n.OK(i18n.SuccessOpenAPIDiff).
+			With("status", "success").
+			With("config", newCfg).
+			With("diff", diff).
+			With("changed", diff.HasChanges()).
+			Send(c)
+		return
+	}
+
+	if existing != nil {
+		if err := h.db.SaveOpenAPIRevision(c.Request.Context(), lookupName, existing); err != nil {
+			h.logger.Error("failed to store previous revision", zap.String("server_name", lookupName), zap.Error(err))
+			i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to store previous revision: "+err.Error()))
+			return
+		}
+		if err := h.store.Update(c.Request.Context(), newCfg); err != nil {
+			h.logger.Error("failed to update MCP server", zap.String("server_name", newCfg.Name), zap.Error(err))
+			i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to update MCP server: "+err.Error()))
+			return
+		}
+	} else if err := h.store.Create(c.Request.Context(), newCfg); err != nil {
+		h.logger.Error("failed to create MCP server", zap.String("server_name", newCfg.Name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to create MCP server: "+err.Error()))
+		return
+	}
+
+	if err := h.notifier.NotifyUpdate(c.Request.Context(), newCfg); err != nil {
+		h.logger.Error("failed to notify gateway", zap.String("server_name", newCfg.Name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to notify gateway: "+err.Error()))
+		return
+	}
+
+	h.logger.Info("OpenAPI upsert import succeeded", zap.String("server_name", newCfg.Name))
+
+	i18n.Created(i18n.SuccessOpenAPIImported).
+		With("status", "success").
+		With("config", newCfg).
+		With("diff", diff).
+		Send(c)
+}
+
+// HandleRollback restores the previous revision of an MCPConfig that was
+// saved by a prior upsert import.
+func (h *OpenAPI) HandleRollback(c *gin.Context) {
+	name := c.Param("name")
+	h.logger.Info("handling OpenAPI rollback request", zap.String("server_name", name))
+
+	previous, err := h.db.GetOpenAPIRevision(c.Request.Context(), name)
+	if err != nil {
+		h.logger.Error("failed to load previous revision", zap.String("server_name", name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrOpenAPINotFound.WithParam("Reason", "No previous revision for "+name))
+		return
+	}
+
+	if err := h.store.Update(c.Request.Context(), previous); err != nil {
+		h.logger.Error("failed to restore previous revision", zap.String("server_name", name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to restore previous revision: "+err.Error()))
+		return
+	}
+
+	if err := h.notifier.NotifyUpdate(c.Request.Context(), previous); err != nil {
+		h.logger.Error("failed to notify gateway", zap.String("server_name", name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to notify gateway: "+err.Error()))
+		return
+	}
+
+	h.logger.Info("OpenAPI rollback succeeded", zap.String("server_name", name))
+
+	i18n.OK(i18n.SuccessOpenAPIRolledBack).
+		With("status", "success").
+		With("config", previous).
+		Send(c)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"http2mcp/internal/common/config"
+)
+
+func TestDiffConfigsNilOldConfigIsAllAdded(t *testing.T) {
+	newCfg := &config.MCPConfig{
+		Tools:   []config.ToolConfig{{Name: "a", Method: "GET", Path: "/a"}},
+		Routers: []config.RouterConfig{{Name: "svc"}},
+	}
+
+	diff := diffConfigs(nil, newCfg)
+
+	if !reflect.DeepEqual(diff.AddedTools, []string{"a"}) {
+		t.Fatalf("AddedTools = %v, want [a]", diff.AddedTools)
+	}
+	if !reflect.DeepEqual(diff.AddedRouters, []string{"svc"}) {
+		t.Fatalf("AddedRouters = %v, want [svc]", diff.AddedRouters)
+	}
+	if len(diff.RemovedTools) != 0 || len(diff.ChangedTools) != 0 {
+		t.Fatalf("expected no removed/changed tools, got %+v", diff)
+	}
+}
+
+func TestDiffConfigsAddedRemovedChanged(t *testing.T) {
+	oldCfg := &config.MCPConfig{
+		Auth: "none",
+		Tools: []config.ToolConfig{
+			{Name: "keep", Method: "GET", Path: "/keep"},
+			{Name: "remove", Method: "GET", Path: "/remove"},
+			{Name: "change", Method: "GET", Path: "/change"},
+		},
+		Routers: []config.RouterConfig{{Name: "old-router"}},
+	}
+	newCfg := &config.MCPConfig{
+		Auth: "bearer",
+		Tools: []config.ToolConfig{
+			{Name: "keep", Method: "GET", Path: "/keep"},
+			{Name: "change", Method: "POST", Path: "/change"},
+			{Name: "added", Method: "GET", Path: "/added"},
+		},
+		Routers: []config.RouterConfig{{Name: "new-router"}},
+	}
+
+	diff := diffConfigs(oldCfg, newCfg)
+
+	sort.Strings(diff.AddedTools)
+	sort.Strings(diff.RemovedTools)
+	sort.Strings(diff.ChangedTools)
+
+	if !reflect.DeepEqual(diff.AddedTools, []string{"added"}) {
+		t.Fatalf("AddedTools = %v, want [added]", diff.AddedTools)
+	}
+	if !reflect.DeepEqual(diff.RemovedTools, []string{"remove"}) {
+		t.Fatalf("RemovedTools = %v, want [remove]", diff.RemovedTools)
+	}
+	if !reflect.DeepEqual(diff.ChangedTools, []string{"change"}) {
+		t.Fatalf("ChangedTools = %v, want [change]", diff.ChangedTools)
+	}
+	if !reflect.DeepEqual(diff.AddedRouters, []string{"new-router"}) {
+		t.Fatalf("AddedRouters = %v, want [new-router]", diff.AddedRouters)
+	}
+	if !reflect.DeepEqual(diff.RemovedRouters, []string{"old-router"}) {
+		t.Fatalf("RemovedRouters = %v, want [old-router]", diff.RemovedRouters)
+	}
+	if !diff.AuthChanged {
+		t.Fatal("AuthChanged = false, want true")
+	}
+	if !diff.HasChanges() {
+		t.Fatal("HasChanges() = false, want true")
+	}
+}
+
+func TestDiffConfigsNoChanges(t *testing.T) {
+	cfg := &config.MCPConfig{
+		Auth:    "none",
+		Tools:   []config.ToolConfig{{Name: "a", Method: "GET", Path: "/a"}},
+		Routers: []config.RouterConfig{{Name: "svc"}},
+	}
+
+	diff := diffConfigs(cfg, cfg)
+	if diff.HasChanges() {
+		t.Fatalf("HasChanges() = true for an unchanged config, got %+v", diff)
+	}
+}
+
+func TestToolsEqual(t *testing.T) {
+	base := config.ToolConfig{Method: "GET", Path: "/a", Description: "desc"}
+
+	cases := []struct {
+		name string
+		a, b config.ToolConfig
+		want bool
+	}{
+		{"identical", base, base, true},
+		{"different method", base, config.ToolConfig{Method: "POST", Path: "/a", Description: "desc"}, false},
+		{"different path", base, config.ToolConfig{Method: "GET", Path: "/b", Description: "desc"}, false},
+		{"different description", base, config.ToolConfig{Method: "GET", Path: "/a", Description: "other"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toolsEqual(tc.a, tc.b); got != tc.want {
+				t.Fatalf("toolsEqual(%+v, %+v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
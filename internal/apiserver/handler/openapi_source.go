@@ -0,0 +1,484 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"github.com/gin-gonic/gin"
+
+	"http2mcp/internal/apiserver/database"
+	"http2mcp/internal/i18n"
+	"http2mcp/internal/mcp/storage"
+	"http2mcp/internal/mcp/storage/notifier"
+	"http2mcp/pkg/openapi"
+)
+
+// SourceType identifies where a remote OpenAPI spec should be fetched from.
+type SourceType string
+
+const (
+	SourceTypeURL      SourceType = "url"
+	SourceTypeGit      SourceType = "git"
+	SourceTypeRegistry SourceType = "registry"
+)
+
+// ImportSourceRequest describes a remote OpenAPI source to import and keep in sync.
+type ImportSourceRequest struct {
+	Type         SourceType `json:"type" binding:"required"`
+	Location     string     `json:"location" binding:"required"`
+	Ref          string     `json:"ref"`
+	Auth         string     `json:"auth"`
+	PollInterval string     `json:"pollInterval"`
+	TenantID     string     `json:"tenantId"`
+	Prefix       string     `json:"prefix"`
+}
+
+// OpenAPISource is the persisted descriptor of a remote source being
+// reconciled; it is defined in package database since that is what stores it.
+type OpenAPISource = database.OpenAPISource
+
+// HandleImportFromSource imports an OpenAPI spec fetched from a remote source
+// (a raw URL, a git repository, or an OCI/registry reference) and registers the
+// source for periodic re-sync instead of requiring a one-off file upload.
+func (h *OpenAPI) HandleImportFromSource(c *gin.Context) {
+	h.logger.Info("handling OpenAPI import-from-source request")
+
+	var req ImportSourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to parse import source request", zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Invalid request body: "+err.Error()))
+		return
+	}
+
+	if _, err := parsePollInterval(req.PollInterval); req.PollInterval != "" && err != nil {
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Invalid pollInterval: "+err.Error()))
+		return
+	}
+
+	content, meta, err := fetchSource(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to fetch remote OpenAPI source",
+			zap.String("type", string(req.Type)), zap.String("location", req.Location), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to fetch source: "+err.Error()))
+		return
+	}
+
+	converter := openapi.NewConverter()
+	config, err := converter.ConvertWithOptions(content, req.TenantID, req.Prefix)
+	if err != nil {
+		h.logger.Error("failed to convert OpenAPI specification", zap.Error(err))
+		h.respondConvertError(c, err)
+		return
+	}
+
+	if err := h.store.Create(c.Request.Context(), config); err != nil {
+		h.logger.Error("failed to create MCP server", zap.String("server_name", config.Name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to create MCP server: "+err.Error()))
+		return
+	}
+
+	source := &OpenAPISource{
+		ID:           config.Name,
+		Type:         string(req.Type),
+		Location:     req.Location,
+		Ref:          req.Ref,
+		Auth:         req.Auth,
+		PollInterval: req.PollInterval,
+		TenantID:     req.TenantID,
+		Prefix:       req.Prefix,
+		ServerName:   config.Name,
+		ContentHash:  hashContent(content),
+		ETag:         meta.etag,
+		LastModified: meta.lastModified,
+		LastSyncedAt: time.Now(),
+	}
+	if err := h.db.SaveOpenAPISource(c.Request.Context(), source); err != nil {
+		h.logger.Error("failed to persist OpenAPI source", zap.String("server_name", config.Name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to persist source: "+err.Error()))
+		return
+	}
+
+	if err := h.notifier.NotifyUpdate(c.Request.Context(), config); err != nil {
+		h.logger.Error("failed to notify gateway", zap.String("server_name", config.Name), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to notify gateway: "+err.Error()))
+		return
+	}
+
+	h.logger.Info("OpenAPI source imported successfully", zap.String("server_name", config.Name))
+
+	i18n.Created(i18n.SuccessOpenAPIImported).
+		With("status", "success").
+		With("config", config).
+		With("source", source).
+		Send(c)
+}
+
+// sourceFetchMeta carries the cache-validation headers returned by an HTTP fetch.
+type sourceFetchMeta struct {
+	etag         string
+	lastModified string
+}
+
+func fetchSource(ctx context.Context, req ImportSourceRequest) ([]byte, sourceFetchMeta, error) {
+	switch req.Type {
+	case SourceTypeURL:
+		return fetchFromURL(ctx, req.Location, "", "", req.Auth)
+	case SourceTypeGit:
+		return fetchFromGit(ctx, req.Location, req.Ref)
+	case SourceTypeRegistry:
+		return fetchFromRegistry(ctx, req.Location, req.Ref, req.Auth)
+	default:
+		return nil, sourceFetchMeta{}, fmt.Errorf("unsupported source type %q", req.Type)
+	}
+}
+
+func fetchFromURL(ctx context.Context, location, etag, lastModified, auth string) ([]byte, sourceFetchMeta, error) {
+	if err := validateRemoteURL(location); err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+	if etag != "" {
+		httpReq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		httpReq.Header.Set("If-Modified-Since", lastModified)
+	}
+	if auth != "" {
+		httpReq.Header.Set("Authorization", auth)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, sourceFetchMeta{etag: etag, lastModified: lastModified}, errNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, sourceFetchMeta{}, fmt.Errorf("unexpected status fetching %s: %s", location, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+
+	return body, sourceFetchMeta{etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}, nil
+}
+
+func fetchFromGit(ctx context.Context, location, ref string) ([]byte, sourceFetchMeta, error) {
+	repo, specPath := splitGitLocation(location)
+	if err := validateGitSource(repo, ref); err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+
+	dir, err := os.MkdirTemp("", "http2mcp-openapi-git-")
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	// "--" stops git from interpreting repo/dir as option flags even though
+	// validateGitSource already rejects dash-prefixed values.
+	args = append(args, "--", repo, dir)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, sourceFetchMeta{}, fmt.Errorf("git clone failed: %w: %s", err, out)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, specPath))
+	if err != nil {
+		return nil, sourceFetchMeta{}, fmt.Errorf("failed to read spec at %q: %w", specPath, err)
+	}
+
+	return content, sourceFetchMeta{}, nil
+}
+
+// splitGitLocation splits a "repo.git//path/to/spec.yaml" location into the
+// clone URL and the in-repo path to the spec file.
+func splitGitLocation(location string) (repo, specPath string) {
+	const sep = "//"
+	if idx := indexAfterGitSuffix(location, sep); idx >= 0 {
+		return location[:idx], location[idx+len(sep):]
+	}
+	return location, "openapi.yaml"
+}
+
+// indexAfterGitSuffix returns the index of the first sep that directly
+// follows a ".git" suffix in s, or -1 if there is none, so the "//" of a
+// scheme such as "https://" is never taken as the separator.
+func indexAfterGitSuffix(s, sep string) int {
+	const suffix = ".git"
+	if idx := strings.Index(s, suffix+sep); idx >= 0 {
+		return idx + len(suffix)
+	}
+	return -1
+}
+
+func fetchFromRegistry(ctx context.Context, location, ref, auth string) ([]byte, sourceFetchMeta, error) {
+	manifestURL := fmt.Sprintf("%s/manifests/%s", location, defaultRef(ref))
+	if err := validateRemoteURL(manifestURL); err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+	httpReq.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
+	if auth != "" {
+		httpReq.Header.Set("Authorization", auth)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, sourceFetchMeta{}, fmt.Errorf("unexpected status pulling manifest from %s: %s", location, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, sourceFetchMeta{}, err
+	}
+
+	return body, sourceFetchMeta{etag: resp.Header.Get("ETag")}, nil
+}
+
+// validateRemoteURL guards fetchFromURL/fetchFromRegistry against SSRF: it
+// requires an https URL and resolves the host up front so a source pointing
+// at loopback, link-local (including the 169.254.169.254 cloud metadata
+// endpoint), or other private addresses is rejected before any request is
+// made. This runs on every fetch, including unattended reconciler polls.
+func validateRemoteURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid source URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("unsupported source URL scheme %q: only https is allowed", u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("source URL is missing a host")
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve source host %q: %w", host, err)
+	}
+	for _, ip := range ips {
+		if isDisallowedRemoteIP(ip) {
+			return fmt.Errorf("source host %q resolves to a disallowed address %s", host, ip)
+		}
+	}
+	return nil
+}
+
+func isDisallowedRemoteIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsPrivate()
+}
+
+// gitScpLikeLocation matches the scp-style syntax git accepts without a
+// scheme, e.g. "git@github.com:org/repo.git".
+var gitScpLikeLocation = regexp.MustCompile(`^[\w.-]+@[\w.-]+:.+$`)
+
+// validateGitSource guards fetchFromGit against argv injection and dangerous
+// git transports: repo/ref values are never allowed to start with "-" (which
+// git would otherwise interpret as an option), and the location must use one
+// of the plain network transports rather than a local/helper one such as
+// "ext::" or "fd::" that could be abused to run arbitrary commands.
+func validateGitSource(repo, ref string) error {
+	if repo == "" {
+		return fmt.Errorf("git source location is empty")
+	}
+	if strings.HasPrefix(repo, "-") {
+		return fmt.Errorf("git source location must not start with %q", "-")
+	}
+	if ref != "" && strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("git source ref must not start with %q", "-")
+	}
+
+	switch {
+	case strings.HasPrefix(repo, "https://"), strings.HasPrefix(repo, "http://"),
+		strings.HasPrefix(repo, "ssh://"), strings.HasPrefix(repo, "git://"):
+		return nil
+	case gitScpLikeLocation.MatchString(repo):
+		return nil
+	default:
+		return fmt.Errorf("unsupported git source transport for location %q", repo)
+	}
+}
+
+func defaultRef(ref string) string {
+	if ref == "" {
+		return "latest"
+	}
+	return ref
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func parsePollInterval(s string) (time.Duration, error) {
+	return time.ParseDuration(s)
+}
+
+var errNotModified = fmt.Errorf("source not modified")
+
+// SourceReconciler periodically revisits stored OpenAPI sources, re-converting
+// and republishing the ones whose upstream content has changed.
+type SourceReconciler struct {
+	db       database.Database
+	store    storage.Store
+	notifier notifier.Notifier
+	logger   *zap.Logger
+
+	tick time.Duration
+}
+
+// NewSourceReconciler creates a reconciler that wakes every 30 seconds and
+// re-syncs each source whose pollInterval has elapsed since its last sync.
+func NewSourceReconciler(db database.Database, store storage.Store, ntf notifier.Notifier, logger *zap.Logger) *SourceReconciler {
+	return &SourceReconciler{
+		db:       db,
+		store:    store,
+		notifier: ntf,
+		logger:   logger,
+		tick:     30 * time.Second,
+	}
+}
+
+// Run blocks, reconciling sources on each tick until ctx is cancelled.
+func (r *SourceReconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcileAll(ctx)
+		}
+	}
+}
+
+func (r *SourceReconciler) reconcileAll(ctx context.Context) {
+	sources, err := r.db.ListOpenAPISources(ctx)
+	if err != nil {
+		r.logger.Error("failed to list OpenAPI sources", zap.Error(err))
+		return
+	}
+
+	for _, source := range sources {
+		interval, err := parsePollInterval(source.PollInterval)
+		if err != nil || interval <= 0 {
+			continue
+		}
+		if time.Since(source.LastSyncedAt) < interval {
+			continue
+		}
+		r.reconcileOne(ctx, source)
+	}
+}
+
+func (r *SourceReconciler) reconcileOne(ctx context.Context, source *OpenAPISource) {
+	req := ImportSourceRequest{
+		Type:     SourceType(source.Type),
+		Location: source.Location,
+		Ref:      source.Ref,
+		Auth:     source.Auth,
+		TenantID: source.TenantID,
+		Prefix:   source.Prefix,
+	}
+
+	var content []byte
+	var meta sourceFetchMeta
+	var err error
+	if SourceType(source.Type) == SourceTypeURL {
+		content, meta, err = fetchFromURL(ctx, source.Location, source.ETag, source.LastModified, source.Auth)
+	} else {
+		content, meta, err = fetchSource(ctx, req)
+	}
+	if err == errNotModified {
+		source.LastSyncedAt = time.Now()
+		if err := r.db.SaveOpenAPISource(ctx, source); err != nil {
+			r.logger.Error("failed to update source sync time", zap.String("server_name", source.ServerName), zap.Error(err))
+		}
+		return
+	}
+	if err != nil {
+		r.logger.Error("failed to fetch OpenAPI source", zap.String("server_name", source.ServerName), zap.Error(err))
+		return
+	}
+
+	hash := hashContent(content)
+	if hash == source.ContentHash {
+		source.LastSyncedAt = time.Now()
+		source.ETag = meta.etag
+		source.LastModified = meta.lastModified
+		if err := r.db.SaveOpenAPISource(ctx, source); err != nil {
+			r.logger.Error("failed to update source sync time", zap.String("server_name", source.ServerName), zap.Error(err))
+		}
+		return
+	}
+
+	converter := openapi.NewConverter()
+	config, err := converter.ConvertWithOptions(content, source.TenantID, source.Prefix)
+	if err != nil {
+		r.logger.Error("failed to reconvert OpenAPI source", zap.String("server_name", source.ServerName), zap.Error(err))
+		return
+	}
+
+	if err := r.store.Update(ctx, config); err != nil {
+		r.logger.Error("failed to update MCP server from source", zap.String("server_name", config.Name), zap.Error(err))
+		return
+	}
+
+	if err := r.notifier.NotifyUpdate(ctx, config); err != nil {
+		r.logger.Error("failed to notify gateway about source update", zap.String("server_name", config.Name), zap.Error(err))
+		return
+	}
+
+	source.ContentHash = hash
+	source.ETag = meta.etag
+	source.LastModified = meta.lastModified
+	source.LastSyncedAt = time.Now()
+	if err := r.db.SaveOpenAPISource(ctx, source); err != nil {
+		r.logger.Error("failed to persist reconciled source", zap.String("server_name", source.ServerName), zap.Error(err))
+		return
+	}
+
+	r.logger.Info("OpenAPI source reconciled", zap.String("server_name", source.ServerName))
+}
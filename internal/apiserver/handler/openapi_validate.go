@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"http2mcp/internal/i18n"
+	"http2mcp/pkg/openapi"
+)
+
+// HandleValidate runs an OpenAPI spec through the converter without
+// persisting anything, so callers such as CI jobs can check a spec before
+// committing to an import. It returns the resolved
+// MCPConfig plus any non-fatal warnings the converter collected.
+func (h *OpenAPI) HandleValidate(c *gin.Context) {
+	h.logger.Info("handling OpenAPI validate request")
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error("failed to get file from request", zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to get file: "+err.Error()))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to open file: "+err.Error()))
+		return
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		h.logger.Error("failed to read file content", zap.String("filename", file.Filename), zap.Error(err))
+		i18n.RespondWithError(c, i18n.ErrInternalServer.WithParam("Reason", "Failed to read file: "+err.Error()))
+		return
+	}
+
+	tenant := c.PostForm("tenantId")
+	prefix := c.PostForm("prefix")
+
+	converter := openapi.NewConverter()
+	cfg, warnings, err := converter.ConvertWithWarnings(content, tenant, prefix)
+	if err != nil {
+		h.respondConvertError(c, err)
+		return
+	}
+
+	h.logger.Info("OpenAPI specification validated successfully", zap.String("server_name", cfg.Name))
+
+	i18n.OK(i18n.SuccessOpenAPIValidated).
+		With("status", "success").
+		With("config", cfg).
+		With("warnings", warnings).
+		Send(c)
+}
+
+// respondConvertError maps a typed pkg/openapi conversion error to an i18n
+// error code and HTTP status. ErrUnsupportedFeature, ErrDuplicateOperationID
+// and ErrInvalidRef each get their own code; ErrParse is reported with the
+// generic ErrBadRequest code, the same as an untyped error.
+func (h *OpenAPI) respondConvertError(c *gin.Context, err error) {
+	var parseErr *openapi.ErrParse
+	var unsupportedErr *openapi.ErrUnsupportedFeature
+	var dupErr *openapi.ErrDuplicateOperationID
+	var refErr *openapi.ErrInvalidRef
+
+	switch {
+	case errors.As(err, &parseErr):
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", err.Error()))
+	case errors.As(err, &unsupportedErr):
+		i18n.RespondWithError(c, i18n.ErrUnsupportedFeature.
+			WithParam("Feature", unsupportedErr.Feature).
+			WithParam("Location", unsupportedErr.Location).
+			WithParam("Reason", err.Error()))
+	case errors.As(err, &dupErr):
+		i18n.RespondWithError(c, i18n.ErrDuplicateOperationID.WithParam("Reason", err.Error()))
+	case errors.As(err, &refErr):
+		i18n.RespondWithError(c, i18n.ErrInvalidRef.WithParam("Ref", refErr.Ref).WithParam("Reason", err.Error()))
+	default:
+		i18n.RespondWithError(c, i18n.ErrBadRequest.WithParam("Reason", "Failed to convert OpenAPI specification: "+err.Error()))
+	}
+}
+
+// convertErrorCode returns a short machine-readable code for a pkg/openapi
+// conversion error. It classifies the same typed errors as
+// respondConvertError, for callers like runImportJob that have no
+// gin.Context to respond on and instead record the failure on a Job.
+func convertErrorCode(err error) string {
+	var parseErr *openapi.ErrParse
+	var unsupportedErr *openapi.ErrUnsupportedFeature
+	var dupErr *openapi.ErrDuplicateOperationID
+	var refErr *openapi.ErrInvalidRef
+
+	switch {
+	case errors.As(err, &parseErr):
+		return "parse_error"
+	case errors.As(err, &unsupportedErr):
+		return "unsupported_feature"
+	case errors.As(err, &dupErr):
+		return "duplicate_operation_id"
+	case errors.As(err, &refErr):
+		return "invalid_ref"
+	default:
+		return "conversion_failed"
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	"http2mcp/pkg/openapi"
+)
+
+func TestConvertErrorCode(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"parse", &openapi.ErrParse{Reason: "bad yaml"}, "parse_error"},
+		{"unsupported feature", &openapi.ErrUnsupportedFeature{Feature: "oidc", Location: "x"}, "unsupported_feature"},
+		{"duplicate operation id", &openapi.ErrDuplicateOperationID{OperationID: "dup"}, "duplicate_operation_id"},
+		{"invalid ref", &openapi.ErrInvalidRef{Ref: "#/bad"}, "invalid_ref"},
+		{"unknown", errors.New("boom"), "conversion_failed"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := convertErrorCode(tc.err); got != tc.want {
+				t.Fatalf("convertErrorCode(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
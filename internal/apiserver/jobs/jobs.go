@@ -0,0 +1,126 @@
+// Package jobs provides a minimal in-memory tracker for long-running
+// background work (currently OpenAPI import conversions) that HTTP handlers
+// can kick off and poll without blocking the request.
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"http2mcp/internal/apiserver/database"
+	"http2mcp/internal/common/config"
+)
+
+// State is the lifecycle state of a tracked job.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+)
+
+// Job is the externally visible record of a background import job.
+type Job struct {
+	ID         string            `json:"id"`
+	State      State             `json:"state"`
+	Progress   int               `json:"progress"`
+	ServerName string            `json:"serverName,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	ErrorCode  string            `json:"errorCode,omitempty"`
+	Config     *config.MCPConfig `json:"config,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
+
+// Manager tracks jobs in memory and mirrors every update to the database via
+// SaveImportJob. It never reads jobs back from the database, so Get and List
+// only see jobs created by this process.
+type Manager struct {
+	db database.Database
+
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewManager creates a job manager backed by db for persistence.
+func NewManager(db database.Database) *Manager {
+	return &Manager{
+		db:   db,
+		jobs: make(map[string]*Job),
+	}
+}
+
+// Create registers a new pending job and returns it.
+func (m *Manager) Create(id string) *Job {
+	now := time.Now()
+	job := &Job{ID: id, State: StatePending, CreatedAt: now, UpdatedAt: now}
+
+	m.mu.Lock()
+	m.jobs[id] = job
+	m.mu.Unlock()
+
+	m.persist(job)
+	return job
+}
+
+// Update mutates a job in place via fn and persists the result.
+func (m *Manager) Update(id string, fn func(job *Job)) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	fn(job)
+	job.UpdatedAt = time.Now()
+	m.mu.Unlock()
+
+	m.persist(job)
+}
+
+// Get returns a snapshot of the job with the given id, if tracked. It returns
+// a copy rather than the pointer stored in the map so callers (typically
+// marshalling the result into an HTTP response) don't race with the
+// background goroutine that keeps mutating the tracked job via Update.
+func (m *Manager) Get(id string) (*Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+// List returns a snapshot of all tracked jobs, most recently created first.
+// As with Get, each entry is a copy so it's safe to read after the call
+// returns even while the corresponding job keeps being updated.
+func (m *Manager) List() []*Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		snapshot := *job
+		jobs = append(jobs, &snapshot)
+	}
+	for i, j := 0, len(jobs); i < j-1; i++ {
+		for k := i + 1; k < j; k++ {
+			if jobs[k].CreatedAt.After(jobs[i].CreatedAt) {
+				jobs[i], jobs[k] = jobs[k], jobs[i]
+			}
+		}
+	}
+	return jobs
+}
+
+func (m *Manager) persist(job *Job) {
+	if m.db == nil {
+		return
+	}
+	_ = m.db.SaveImportJob(context.Background(), job.ID, job)
+}
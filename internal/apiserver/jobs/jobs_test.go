@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestManagerCreateGetUpdate(t *testing.T) {
+	m := NewManager(nil)
+
+	job := m.Create("job-1")
+	if job.State != StatePending {
+		t.Fatalf("Create: got state %q, want %q", job.State, StatePending)
+	}
+
+	m.Update("job-1", func(j *Job) {
+		j.State = StateRunning
+		j.Progress = 50
+	})
+
+	got, ok := m.Get("job-1")
+	if !ok {
+		t.Fatal("Get: job not found")
+	}
+	if got.State != StateRunning || got.Progress != 50 {
+		t.Fatalf("Get: got %+v, want State=running Progress=50", got)
+	}
+}
+
+func TestManagerGetUnknownJob(t *testing.T) {
+	m := NewManager(nil)
+	if _, ok := m.Get("missing"); ok {
+		t.Fatal("Get: expected ok=false for an untracked job")
+	}
+}
+
+func TestManagerListOrdersMostRecentFirst(t *testing.T) {
+	m := NewManager(nil)
+	m.Create("job-1")
+	m.Create("job-2")
+	m.Create("job-3")
+
+	list := m.List()
+	if len(list) != 3 {
+		t.Fatalf("List: got %d jobs, want 3", len(list))
+	}
+	if list[0].ID != "job-3" || list[2].ID != "job-1" {
+		t.Fatalf("List: got order %v, want most recently created first", []string{list[0].ID, list[1].ID, list[2].ID})
+	}
+}
+
+// TestManagerGetReturnsSnapshot ensures Get hands back a copy rather than the
+// live pointer Update keeps mutating, so a caller reading the result later
+// doesn't race with (or see a torn view of) a concurrent Update.
+func TestManagerGetReturnsSnapshot(t *testing.T) {
+	m := NewManager(nil)
+	m.Create("job-1")
+
+	snapshot, ok := m.Get("job-1")
+	if !ok {
+		t.Fatal("Get: job not found")
+	}
+
+	m.Update("job-1", func(j *Job) { j.State = StateSucceeded })
+
+	if snapshot.State != StatePending {
+		t.Fatalf("snapshot mutated after Update: got state %q, want it to stay %q", snapshot.State, StatePending)
+	}
+}
+
+func TestManagerConcurrentUpdateAndRead(t *testing.T) {
+	m := NewManager(nil)
+	m.Create("job-1")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(progress int) {
+			defer wg.Done()
+			m.Update("job-1", func(j *Job) { j.Progress = progress })
+		}(i)
+		go func() {
+			defer wg.Done()
+			m.Get("job-1")
+			m.List()
+		}()
+	}
+	wg.Wait()
+}
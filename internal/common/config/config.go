@@ -0,0 +1,29 @@
+// Package config holds the shared MCP server configuration types produced by
+// the OpenAPI converter and persisted via storage.Store.
+package config
+
+// MCPConfig describes a single MCP server generated from (or otherwise
+// backing) an API: its tools, the routers that expose them, and any auth
+// required to reach the upstream API.
+type MCPConfig struct {
+	Name    string         `json:"name"`
+	Tenant  string         `json:"tenant,omitempty"`
+	Prefix  string         `json:"prefix,omitempty"`
+	Tools   []ToolConfig   `json:"tools"`
+	Routers []RouterConfig `json:"routers,omitempty"`
+	Auth    string         `json:"auth,omitempty"`
+}
+
+// ToolConfig describes a single MCP tool backed by one API operation.
+type ToolConfig struct {
+	Name        string `json:"name"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+}
+
+// RouterConfig describes a named group of tools exposed under a prefix.
+type RouterConfig struct {
+	Name   string `json:"name"`
+	Prefix string `json:"prefix,omitempty"`
+}
@@ -0,0 +1,25 @@
+package i18n
+
+import "net/http"
+
+// Success codes for the asynchronous import, bulk-import, validate, and
+// upsert-import/rollback OpenAPI endpoints.
+var (
+	SuccessOpenAPIImportQueued = newSuccessCode("OPENAPI_IMPORT_QUEUED")
+	SuccessOpenAPIJobStatus    = newSuccessCode("OPENAPI_IMPORT_JOB_STATUS")
+	SuccessOpenAPIBulkImported = newSuccessCode("OPENAPI_BULK_IMPORTED")
+	SuccessOpenAPIValidated    = newSuccessCode("OPENAPI_VALIDATED")
+	SuccessOpenAPIDiff         = newSuccessCode("OPENAPI_IMPORT_DIFF")
+	SuccessOpenAPIRolledBack   = newSuccessCode("OPENAPI_IMPORT_ROLLED_BACK")
+)
+
+// Error codes for the OpenAPI endpoints: ErrOpenAPINotFound for an unknown
+// import job or revision, and the rest distinguishing ways an OpenAPI
+// conversion can fail, so clients can react programmatically instead of
+// string-matching a message.
+var (
+	ErrOpenAPINotFound      = newErrorCode("OPENAPI_NOT_FOUND", http.StatusNotFound)
+	ErrUnsupportedFeature   = newErrorCode("OPENAPI_UNSUPPORTED_FEATURE", http.StatusUnprocessableEntity)
+	ErrDuplicateOperationID = newErrorCode("OPENAPI_DUPLICATE_OPERATION_ID", http.StatusConflict)
+	ErrInvalidRef           = newErrorCode("OPENAPI_INVALID_REF", http.StatusBadRequest)
+)
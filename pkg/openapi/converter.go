@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"http2mcp/internal/common/config"
+)
+
+// Converter turns an OpenAPI/Swagger document into an MCPConfig, one tool per
+// operation.
+type Converter struct{}
+
+// NewConverter creates a Converter.
+func NewConverter() *Converter {
+	return &Converter{}
+}
+
+// Convert converts content with no tenant and no tool-name prefix: the
+// document's title becomes the server name and operations keep their own
+// operationId.
+func (c *Converter) Convert(content []byte) (*config.MCPConfig, error) {
+	return c.ConvertWithOptions(content, "", "")
+}
+
+// ConvertWithOptions converts content under the given tenant and tool-name
+// prefix. A non-empty prefix is prepended to every tool name and also
+// replaces the title-derived server name.
+func (c *Converter) ConvertWithOptions(content []byte, tenant, prefix string) (*config.MCPConfig, error) {
+	cfg, _, err := c.convert(content, tenant, prefix)
+	return cfg, err
+}
+
+// ConvertWithWarnings behaves like ConvertWithOptions but also returns the
+// non-fatal issues noticed along the way (an auto-generated operationId, two
+// paths that resolve to the same route pattern, ...), for callers such as
+// HandleValidate that want to surface them without failing the conversion.
+func (c *Converter) ConvertWithWarnings(content []byte, tenant, prefix string) (*config.MCPConfig, []string, error) {
+	return c.convert(content, tenant, prefix)
+}
+
+func (c *Converter) convert(content []byte, tenant, prefix string) (*config.MCPConfig, []string, error) {
+	var doc rawDocument
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, &ErrParse{Reason: err.Error()}
+	}
+	if doc.OpenAPI == "" && doc.Swagger == "" {
+		return nil, nil, &ErrParse{Reason: "document is missing an openapi/swagger version field"}
+	}
+
+	for name, scheme := range doc.Components.SecuritySchemes {
+		if !isSupportedAuthScheme(scheme) {
+			return nil, nil, &ErrUnsupportedFeature{
+				Feature:  fmt.Sprintf("auth scheme %q (type %s)", name, scheme.Type),
+				Location: "components.securitySchemes." + name,
+			}
+		}
+	}
+
+	var warnings []string
+	seenOperationIDs := make(map[string]bool)
+	seenPatterns := make(map[string]string) // method+normalized path -> first raw path seen
+	var tools []config.ToolConfig
+	for _, path := range sortedKeys(doc.Paths) {
+		methods := doc.Paths[path]
+		for _, method := range sortedKeys(methods) {
+			op := methods[method]
+
+			for _, param := range op.Parameters {
+				if param.Ref != "" && !strings.HasPrefix(param.Ref, "#/components/") {
+					return nil, nil, &ErrInvalidRef{Ref: param.Ref}
+				}
+			}
+
+			operationID := op.OperationID
+			if operationID == "" {
+				operationID = generateOperationID(method, path)
+				warnings = append(warnings, fmt.Sprintf(
+					"%s %s is missing operationId; generated %q instead", strings.ToUpper(method), path, operationID))
+			} else if seenOperationIDs[operationID] {
+				return nil, nil, &ErrDuplicateOperationID{OperationID: operationID}
+			}
+			seenOperationIDs[operationID] = true
+
+			patternKey := strings.ToUpper(method) + " " + normalizePathParams(path)
+			if firstPath, seen := seenPatterns[patternKey]; seen && firstPath != path {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s %s has ambiguous path parameters: it matches the same requests as %s %s", strings.ToUpper(method), path, strings.ToUpper(method), firstPath))
+			} else if !seen {
+				seenPatterns[patternKey] = path
+			}
+
+			tools = append(tools, config.ToolConfig{
+				Name:        applyPrefix(prefix, operationID),
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				Description: firstNonEmpty(op.Summary, op.Description),
+			})
+		}
+	}
+
+	name := prefix
+	if name == "" {
+		name = slugify(doc.Info.Title)
+	}
+	if name == "" {
+		name = "openapi-server"
+	}
+
+	cfg := &config.MCPConfig{
+		Name:   name,
+		Tenant: tenant,
+		Prefix: prefix,
+		Tools:  tools,
+		Routers: []config.RouterConfig{
+			{Name: name, Prefix: prefix},
+		},
+	}
+	return cfg, warnings, nil
+}
+
+// pathParamPattern matches an OpenAPI path template parameter, e.g. "{id}".
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// normalizePathParams collapses every path parameter to a placeholder so two
+// differently-named parameters at the same position (e.g. "/users/{id}" and
+// "/users/{userId}") are recognized as the same route pattern.
+func normalizePathParams(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+// rawDocument is the minimal subset of an OpenAPI/Swagger document the
+// converter needs; everything else is ignored.
+type rawDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title string `yaml:"title"`
+	} `yaml:"info"`
+	Paths      map[string]map[string]rawOperation `yaml:"paths"`
+	Components struct {
+		SecuritySchemes map[string]rawSecurityScheme `yaml:"securitySchemes"`
+	} `yaml:"components"`
+}
+
+type rawOperation struct {
+	OperationID string         `yaml:"operationId"`
+	Summary     string         `yaml:"summary"`
+	Description string         `yaml:"description"`
+	Parameters  []rawParameter `yaml:"parameters"`
+}
+
+type rawParameter struct {
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+	Ref  string `yaml:"$ref"`
+}
+
+type rawSecurityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+}
+
+// supportedAuthSchemes lists the auth scheme/sub-scheme combinations the
+// converter knows how to translate into outbound request auth.
+var supportedAuthSchemes = map[string]bool{
+	"apiKey":      true,
+	"http:basic":  true,
+	"http:bearer": true,
+	"oauth2":      true,
+}
+
+func isSupportedAuthScheme(scheme rawSecurityScheme) bool {
+	key := scheme.Type
+	if scheme.Scheme != "" {
+		key = scheme.Type + ":" + strings.ToLower(scheme.Scheme)
+	}
+	return supportedAuthSchemes[key]
+}
+
+func generateOperationID(method, path string) string {
+	cleaned := strings.NewReplacer("/", "_", "{", "", "}", "").Replace(strings.Trim(path, "/"))
+	return strings.ToLower(method) + "_" + cleaned
+}
+
+func applyPrefix(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func slugify(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "-", "_", "-").Replace(s)
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
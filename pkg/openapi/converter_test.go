@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const minimalSpec = `
+openapi: "3.0.0"
+info:
+  title: Pet Store
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+    post:
+      operationId: createPet
+  /pets/{id}:
+    get:
+      operationId: getPet
+`
+
+func TestConvertWithOptions(t *testing.T) {
+	cfg, err := NewConverter().ConvertWithOptions([]byte(minimalSpec), "tenant-a", "store")
+	if err != nil {
+		t.Fatalf("ConvertWithOptions: unexpected error: %v", err)
+	}
+	if cfg.Tenant != "tenant-a" || cfg.Prefix != "store" {
+		t.Fatalf("ConvertWithOptions: got tenant=%q prefix=%q", cfg.Tenant, cfg.Prefix)
+	}
+	if len(cfg.Tools) != 3 {
+		t.Fatalf("ConvertWithOptions: got %d tools, want 3", len(cfg.Tools))
+	}
+	if cfg.Tools[0].Name != "store_listPets" {
+		t.Fatalf("ConvertWithOptions: got tool name %q, want prefixed operationId", cfg.Tools[0].Name)
+	}
+}
+
+func TestConvertMissingVersionField(t *testing.T) {
+	_, err := NewConverter().Convert([]byte("info:\n  title: no version"))
+	var parseErr *ErrParse
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Convert: got error %v, want *ErrParse", err)
+	}
+}
+
+func TestConvertDuplicateOperationID(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: Dup
+paths:
+  /a:
+    get:
+      operationId: same
+  /b:
+    get:
+      operationId: same
+`
+	_, err := NewConverter().Convert([]byte(spec))
+	var dupErr *ErrDuplicateOperationID
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("Convert: got error %v, want *ErrDuplicateOperationID", err)
+	}
+	if dupErr.OperationID != "same" {
+		t.Fatalf("Convert: got OperationID %q, want %q", dupErr.OperationID, "same")
+	}
+}
+
+func TestConvertInvalidRef(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: Refs
+paths:
+  /a:
+    get:
+      operationId: getA
+      parameters:
+        - $ref: "external.yaml#/components/parameters/Foo"
+`
+	_, err := NewConverter().Convert([]byte(spec))
+	var refErr *ErrInvalidRef
+	if !errors.As(err, &refErr) {
+		t.Fatalf("Convert: got error %v, want *ErrInvalidRef", err)
+	}
+}
+
+func TestConvertUnsupportedAuthScheme(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: Auth
+paths: {}
+components:
+  securitySchemes:
+    weird:
+      type: openIdConnect
+`
+	_, err := NewConverter().Convert([]byte(spec))
+	var unsupportedErr *ErrUnsupportedFeature
+	if !errors.As(err, &unsupportedErr) {
+		t.Fatalf("Convert: got error %v, want *ErrUnsupportedFeature", err)
+	}
+}
+
+func TestConvertWithWarningsGeneratedOperationID(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: NoIDs
+paths:
+  /pets:
+    get: {}
+`
+	_, warnings, err := NewConverter().ConvertWithWarnings([]byte(spec), "", "")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings: unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "missing operationId") {
+		t.Fatalf("ConvertWithWarnings: got warnings %v, want one about a missing operationId", warnings)
+	}
+}
+
+func TestConvertWithWarningsAmbiguousPathParams(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: Ambiguous
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPetById
+  /pets/{petId}:
+    get:
+      operationId: getPetByPetId
+`
+	_, warnings, err := NewConverter().ConvertWithWarnings([]byte(spec), "", "")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings: unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "ambiguous path parameters") {
+		t.Fatalf("ConvertWithWarnings: got warnings %v, want one about ambiguous path parameters", warnings)
+	}
+}
+
+func TestConvertWithWarningsNoWarningsForCleanSpec(t *testing.T) {
+	_, warnings, err := NewConverter().ConvertWithWarnings([]byte(minimalSpec), "", "")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings: unexpected error: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("ConvertWithWarnings: got warnings %v, want none", warnings)
+	}
+}
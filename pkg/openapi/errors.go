@@ -0,0 +1,43 @@
+package openapi
+
+import "fmt"
+
+// ErrParse indicates the input could not be parsed as OpenAPI/Swagger at all
+// (malformed JSON/YAML, wrong document type, etc).
+type ErrParse struct {
+	Reason string
+}
+
+func (e *ErrParse) Error() string {
+	return fmt.Sprintf("failed to parse OpenAPI document: %s", e.Reason)
+}
+
+// ErrUnsupportedFeature indicates the spec uses a construct the converter
+// does not (yet) know how to translate into an MCP tool definition.
+type ErrUnsupportedFeature struct {
+	Feature  string
+	Location string
+}
+
+func (e *ErrUnsupportedFeature) Error() string {
+	return fmt.Sprintf("unsupported feature %q at %s", e.Feature, e.Location)
+}
+
+// ErrDuplicateOperationID indicates two operations in the same document share
+// an operationId, so the converter cannot name their tools unambiguously.
+type ErrDuplicateOperationID struct {
+	OperationID string
+}
+
+func (e *ErrDuplicateOperationID) Error() string {
+	return fmt.Sprintf("duplicate operationId %q", e.OperationID)
+}
+
+// ErrInvalidRef indicates a $ref could not be resolved within the document.
+type ErrInvalidRef struct {
+	Ref string
+}
+
+func (e *ErrInvalidRef) Error() string {
+	return fmt.Sprintf("invalid $ref %q", e.Ref)
+}